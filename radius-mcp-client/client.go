@@ -0,0 +1,262 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync/atomic"
+)
+
+// Client is a minimal Model Context Protocol client. It owns JSON-RPC
+// request-ID allocation and correlates responses to requests by ID,
+// delegating the actual wire work to a Transport.
+type Client struct {
+	transport Transport
+	nextID    int64
+}
+
+// NewClient returns a Client that talks to an MCP server over transport.
+func NewClient(transport Transport) *Client {
+	return &Client{transport: transport}
+}
+
+// Initialize performs the MCP initialize handshake: it negotiates the
+// protocol version and capabilities, then sends the required
+// notifications/initialized notification.
+func (c *Client) Initialize(ctx context.Context) (*InitializeResult, error) {
+	params := InitializeParams{
+		ProtocolVersion: mcpProtocolVersion,
+		Capabilities:    ClientCapabilities{},
+		ClientInfo: Implementation{
+			Name:    "radius-mcp-client",
+			Version: "0.1.0",
+		},
+	}
+
+	var result InitializeResult
+	if err := c.call(ctx, "initialize", params, &result); err != nil {
+		return nil, err
+	}
+
+	if err := c.notify(ctx, "notifications/initialized", nil); err != nil {
+		return nil, fmt.Errorf("sending initialized notification: %w", err)
+	}
+
+	return &result, nil
+}
+
+// ListTools returns the tools the server currently exposes.
+func (c *Client) ListTools(ctx context.Context) ([]Tool, error) {
+	var result ToolsListResult
+	if err := c.call(ctx, "tools/list", nil, &result); err != nil {
+		return nil, err
+	}
+	return result.Tools, nil
+}
+
+// CallTool invokes a single tool and waits for its result.
+func (c *Client) CallTool(ctx context.Context, name string, arguments map[string]interface{}) (*ToolsCallResult, error) {
+	params := ToolsCallParams{
+		Name:      name,
+		Arguments: arguments,
+	}
+
+	var result ToolsCallResult
+	if err := c.call(ctx, "tools/call", params, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// CallToolStream invokes a tool and returns a channel of its incremental
+// events (progress, log lines, partial output) followed by a terminal
+// ToolEventResult or ToolEventError. The channel is closed once the
+// terminal event has been sent.
+//
+// On transports with their own notification channel (stdio, SSE) this
+// correlates notifications/progress and notifications/message frames by
+// progress token. On HTTPTransport, which has no such channel, it falls
+// back to ChunkedTransport's chunked-response framing.
+func (c *Client) CallToolStream(ctx context.Context, name string, arguments map[string]interface{}) (<-chan ToolEvent, error) {
+	id := atomic.AddInt64(&c.nextID, 1)
+	progressToken := fmt.Sprintf("call-%d", id)
+
+	req := JSONRPCRequest{
+		JSONRPC: jsonRPCVersion,
+		ID:      &id,
+		Method:  "tools/call",
+		Params: ToolsCallParams{
+			Name:      name,
+			Arguments: arguments,
+			Meta:      &RequestMeta{ProgressToken: progressToken},
+		},
+	}
+
+	if chunked, ok := c.transport.(ChunkedTransport); ok {
+		raw, err := chunked.SendChunked(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		return translateStreamEvents(raw), nil
+	}
+
+	return c.streamViaNotifications(ctx, req, progressToken), nil
+}
+
+// streamViaNotifications subscribes to progressToken on the transport's
+// notification broker before sending req, so no notification can arrive
+// and be dropped before we're listening for it. It forwards subscribed
+// events as ToolEvents until the call's own response arrives. Because
+// each call subscribes under its own token, two concurrent
+// CallToolStream calls on the same Client never see each other's
+// events.
+func (c *Client) streamViaNotifications(ctx context.Context, req JSONRPCRequest, progressToken string) <-chan ToolEvent {
+	events := make(chan ToolEvent, 16)
+	notifs, cancel := c.transport.Subscribe(progressToken)
+
+	go func() {
+		defer close(events)
+		defer cancel()
+
+		type sendResult struct {
+			resp *JSONRPCResponse
+			err  error
+		}
+		resultCh := make(chan sendResult, 1)
+		go func() {
+			resp, err := c.transport.Send(ctx, req)
+			resultCh <- sendResult{resp, err}
+		}()
+
+		for {
+			select {
+			case ev, ok := <-notifs:
+				if !ok {
+					notifs = nil
+					continue
+				}
+				events <- ev
+
+			case res := <-resultCh:
+				if res.err != nil {
+					events <- ToolEvent{Kind: ToolEventError, Err: res.err}
+					return
+				}
+				if res.resp != nil && res.resp.Error != nil {
+					events <- ToolEvent{Kind: ToolEventError, Err: res.resp.Error}
+					return
+				}
+
+				var result ToolsCallResult
+				if res.resp != nil && len(res.resp.Result) > 0 {
+					if err := json.Unmarshal(res.resp.Result, &result); err != nil {
+						events <- ToolEvent{Kind: ToolEventError, Err: fmt.Errorf("decoding tools/call result: %w", err)}
+						return
+					}
+				}
+				events <- ToolEvent{Kind: ToolEventResult, Result: &result}
+				return
+			}
+		}
+	}()
+
+	return events
+}
+
+// translateStreamEvents adapts ChunkedTransport's StreamEvent framing to
+// the same ToolEvent channel streamViaNotifications produces.
+func translateStreamEvents(raw <-chan StreamEvent) <-chan ToolEvent {
+	events := make(chan ToolEvent, 16)
+
+	go func() {
+		defer close(events)
+		terminal := false
+		for ev := range raw {
+			if terminal {
+				continue
+			}
+			switch ev.Type {
+			case "progress":
+				events <- ToolEvent{Kind: ToolEventProgress, ProgressToken: ev.ProgressToken, Progress: ev.Progress, Message: ev.Message}
+			case "log":
+				events <- ToolEvent{Kind: ToolEventLog, ProgressToken: ev.ProgressToken, Message: ev.Message}
+			case "partialOutput":
+				events <- ToolEvent{Kind: ToolEventPartialOutput, ProgressToken: ev.ProgressToken, Message: ev.Message}
+			case "error":
+				terminal = true
+				events <- ToolEvent{Kind: ToolEventError, Err: ev.Error}
+			case "result":
+				terminal = true
+				var result ToolsCallResult
+				if len(ev.Result) > 0 {
+					_ = json.Unmarshal(ev.Result, &result)
+				}
+				events <- ToolEvent{Kind: ToolEventResult, Result: &result}
+			}
+		}
+		if !terminal {
+			events <- ToolEvent{Kind: ToolEventError, Err: fmt.Errorf("chunked stream ended without a result")}
+		}
+	}()
+
+	return events
+}
+
+// Ping round-trips a no-op request, useful for liveness checks.
+func (c *Client) Ping(ctx context.Context) error {
+	return c.call(ctx, "ping", nil, nil)
+}
+
+// call sends a JSON-RPC request and decodes its result into v. v may be
+// nil when the caller doesn't need the result payload.
+func (c *Client) call(ctx context.Context, method string, params interface{}, v interface{}) error {
+	id := atomic.AddInt64(&c.nextID, 1)
+
+	resp, err := c.send(ctx, JSONRPCRequest{
+		JSONRPC: jsonRPCVersion,
+		ID:      &id,
+		Method:  method,
+		Params:  params,
+	})
+	if err != nil {
+		return err
+	}
+
+	if resp.Error != nil {
+		return resp.Error
+	}
+
+	if v == nil || len(resp.Result) == 0 {
+		return nil
+	}
+
+	if err := json.Unmarshal(resp.Result, v); err != nil {
+		return fmt.Errorf("decoding result for %s: %w", method, err)
+	}
+
+	return nil
+}
+
+// notify sends a JSON-RPC notification. Notifications carry no ID and
+// receive no response.
+func (c *Client) notify(ctx context.Context, method string, params interface{}) error {
+	_, err := c.send(ctx, JSONRPCRequest{
+		JSONRPC: jsonRPCVersion,
+		Method:  method,
+		Params:  params,
+	})
+	return err
+}
+
+// send hands req to the transport. Notifications get an empty
+// *JSONRPCResponse back since the server must not reply to them.
+func (c *Client) send(ctx context.Context, req JSONRPCRequest) (*JSONRPCResponse, error) {
+	resp, err := c.transport.Send(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return &JSONRPCResponse{}, nil
+	}
+	return resp, nil
+}
@@ -0,0 +1,111 @@
+package main
+
+import "encoding/json"
+
+// ToolEventKind discriminates the events a streaming tool call can emit.
+type ToolEventKind int
+
+const (
+	// ToolEventProgress carries a progress update (percent complete).
+	ToolEventProgress ToolEventKind = iota
+	// ToolEventLog carries a single log line from the tool's execution.
+	ToolEventLog
+	// ToolEventPartialOutput carries a chunk of the tool's streamed output.
+	ToolEventPartialOutput
+	// ToolEventResult carries the final, successful tool result.
+	ToolEventResult
+	// ToolEventError carries the terminal error for the call.
+	ToolEventError
+)
+
+// ToolEvent is one increment of a streaming tool call. Exactly one of
+// Result or Err is set for the terminal event (ToolEventResult /
+// ToolEventError); the call's event channel is closed right after.
+type ToolEvent struct {
+	Kind ToolEventKind
+
+	ProgressToken string
+	Progress      float64 // percent complete, 0-100; only set for ToolEventProgress
+	Message       string  // log line or partial output text
+
+	Result *ToolsCallResult
+	Err    error
+}
+
+// ProgressParams is the payload of a "notifications/progress" message.
+type ProgressParams struct {
+	ProgressToken string  `json:"progressToken"`
+	Progress      float64 `json:"progress"`
+	Total         float64 `json:"total,omitempty"`
+	Message       string  `json:"message,omitempty"`
+}
+
+// LogMessageParams is the payload of a "notifications/message" message.
+// ProgressToken is a radius-mcp extension used to correlate a log line
+// with the tool call that produced it.
+type LogMessageParams struct {
+	Level         string          `json:"level"`
+	Logger        string          `json:"logger,omitempty"`
+	Data          json.RawMessage `json:"data"`
+	ProgressToken string          `json:"progressToken,omitempty"`
+}
+
+// RequestMeta is the standard MCP "_meta" envelope, used here to attach
+// a progress token to a tools/call request.
+type RequestMeta struct {
+	ProgressToken string `json:"progressToken,omitempty"`
+}
+
+// StreamEvent is one line of a chunked-HTTP tool-call stream: the plain
+// HTTP transport has no independent notification channel, so it encodes
+// progress/log/partialOutput/result/error as one JSON object per chunk
+// instead of MCP notifications.
+type StreamEvent struct {
+	Type          string          `json:"type"`
+	ProgressToken string          `json:"progressToken,omitempty"`
+	Progress      float64         `json:"progress,omitempty"`
+	Message       string          `json:"message,omitempty"`
+	Result        json.RawMessage `json:"result,omitempty"`
+	Error         *JSONRPCError   `json:"error,omitempty"`
+}
+
+// decodeNotificationEvent turns a server-initiated "notifications/progress"
+// or "notifications/message" frame into the progress token it's tagged
+// with and the ToolEvent it represents. It returns ok=false for methods
+// this client doesn't understand or frames with no progress token to
+// route by.
+func decodeNotificationEvent(method string, rawParams json.RawMessage) (token string, ev ToolEvent, ok bool) {
+	switch method {
+	case "notifications/progress":
+		var p ProgressParams
+		if err := json.Unmarshal(rawParams, &p); err != nil || p.ProgressToken == "" {
+			return "", ToolEvent{}, false
+		}
+		percent := p.Progress
+		if p.Total > 0 {
+			percent = p.Progress / p.Total * 100
+		}
+		return p.ProgressToken, ToolEvent{
+			Kind:          ToolEventProgress,
+			ProgressToken: p.ProgressToken,
+			Progress:      percent,
+			Message:       p.Message,
+		}, true
+
+	case "notifications/message":
+		var lp LogMessageParams
+		if err := json.Unmarshal(rawParams, &lp); err != nil || lp.ProgressToken == "" {
+			return "", ToolEvent{}, false
+		}
+		var text string
+		_ = json.Unmarshal(lp.Data, &text)
+		return lp.ProgressToken, ToolEvent{
+			Kind:          ToolEventLog,
+			ProgressToken: lp.ProgressToken,
+			Message:       text,
+		}, true
+
+	default:
+		return "", ToolEvent{}, false
+	}
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// jsonRPCVersion is the JSON-RPC version MCP messages are framed in.
+const jsonRPCVersion = "2.0"
+
+// mcpProtocolVersion is the version of the Model Context Protocol this
+// client negotiates during initialize.
+const mcpProtocolVersion = "2024-11-05"
+
+// JSONRPCRequest is a JSON-RPC 2.0 request. A nil ID marks it as a
+// notification, which the server must not reply to.
+type JSONRPCRequest struct {
+	JSONRPC string      `json:"jsonrpc"`
+	ID      *int64      `json:"id,omitempty"`
+	Method  string      `json:"method"`
+	Params  interface{} `json:"params,omitempty"`
+}
+
+// JSONRPCResponse is a JSON-RPC 2.0 response.
+type JSONRPCResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      *int64          `json:"id,omitempty"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *JSONRPCError   `json:"error,omitempty"`
+}
+
+// JSONRPCError is a JSON-RPC 2.0 error object.
+type JSONRPCError struct {
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data,omitempty"`
+}
+
+func (e *JSONRPCError) Error() string {
+	return fmt.Sprintf("mcp: %s (code %d)", e.Message, e.Code)
+}
+
+// Implementation identifies the client or server taking part in the
+// session, as exchanged during initialize.
+type Implementation struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// ClientCapabilities advertises the features this client supports. It is
+// empty for now; fields land as the client grows support for roots,
+// sampling, etc.
+type ClientCapabilities struct{}
+
+// ServerCapabilities advertises the features the server supports.
+type ServerCapabilities struct {
+	Tools *struct {
+		ListChanged bool `json:"listChanged,omitempty"`
+	} `json:"tools,omitempty"`
+}
+
+// InitializeParams are the params for the "initialize" method.
+type InitializeParams struct {
+	ProtocolVersion string             `json:"protocolVersion"`
+	Capabilities    ClientCapabilities `json:"capabilities"`
+	ClientInfo      Implementation     `json:"clientInfo"`
+}
+
+// InitializeResult is the result of the "initialize" method.
+type InitializeResult struct {
+	ProtocolVersion string             `json:"protocolVersion"`
+	Capabilities    ServerCapabilities `json:"capabilities"`
+	ServerInfo      Implementation     `json:"serverInfo"`
+}
+
+// Tool describes a single tool the server exposes.
+type Tool struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	InputSchema json.RawMessage `json:"inputSchema,omitempty"`
+}
+
+// ToolsListResult is the result of the "tools/list" method.
+type ToolsListResult struct {
+	Tools []Tool `json:"tools"`
+}
+
+// ToolsCallParams are the params for the "tools/call" method.
+type ToolsCallParams struct {
+	Name      string                 `json:"name"`
+	Arguments map[string]interface{} `json:"arguments,omitempty"`
+	Meta      *RequestMeta           `json:"_meta,omitempty"`
+}
+
+// ToolContent is one piece of content in a tool call result, e.g. text
+// output or structured data.
+type ToolContent struct {
+	Type string          `json:"type"`
+	Text string          `json:"text,omitempty"`
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+// ToolsCallResult is the result of the "tools/call" method.
+type ToolsCallResult struct {
+	Content []ToolContent `json:"content"`
+	IsError bool          `json:"isError,omitempty"`
+}
@@ -1,147 +1,183 @@
 package main
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
+	"flag"
 	"fmt"
-	"io"
-	"net/http"
 	"os"
+	"strings"
 )
 
-type MCPRequest struct {
-	Version     string                 `json:"version"`
-	MessageType string                 `json:"messageType"`
-	Message     map[string]interface{} `json:"message"`
-}
-
-type MCPResponse struct {
-	Version     string                 `json:"version"`
-	MessageType string                 `json:"messageType"`
-	Message     map[string]interface{} `json:"message"`
-}
-
 func main() {
+	transportName := flag.String("transport", "http", "transport to use: http, stdio, sse, or unix (auto-detected from a unix:// server URL)")
+	flag.Parse()
+
 	serverURL := "http://localhost:8085/mcp2"
-	if len(os.Args) > 1 {
-		serverURL = os.Args[1]
+	if flag.NArg() > 0 {
+		serverURL = flag.Arg(0)
 	}
 
-	// Initialize the server
-	initResponse, err := sendInitializeRequest(serverURL)
+	ctx := context.Background()
+
+	transport, err := newTransport(ctx, *transportName, serverURL)
 	if err != nil {
+		fmt.Printf("Error setting up %s transport: %v\n", *transportName, err)
+		os.Exit(1)
+	}
+	defer transport.Close()
+
+	client := NewClient(transport)
+
+	if _, err := sendInitializeRequest(ctx, client); err != nil {
 		fmt.Printf("Error initializing server: %v\n", err)
 		os.Exit(1)
 	}
 
-	tools := initResponse.Message["tools"].([]interface{})
+	tools, err := client.ListTools(ctx)
+	if err != nil {
+		fmt.Printf("Error listing tools: %v\n", err)
+		os.Exit(1)
+	}
 	fmt.Println("Available tools:")
-	for _, t := range tools {
-		tool := t.(map[string]interface{})
-		fmt.Printf("- %s: %s\n", tool["name"], tool["description"])
+	for _, tool := range tools {
+		fmt.Printf("- %s: %s\n", tool.Name, tool.Description)
 	}
 	fmt.Println()
 
 	// Example: Call the version tool
-	versionResponse, err := sendToolCallRequest(serverURL, "radius_version", map[string]interface{}{})
+	versionResult, err := sendToolCallRequest(ctx, client, "radius_version", map[string]interface{}{})
 	if err != nil {
 		fmt.Printf("Error calling version tool: %v\n", err)
 	} else {
-		printToolResponse("radius_version", versionResponse)
+		printToolResponse("radius_version", versionResult)
 	}
 
-	// Example: List applications
-	listAppsResponse, err := sendToolCallRequest(serverURL, "radius_list_applications", map[string]interface{}{
+	// Example: List applications, streaming log output as it arrives.
+	if err := streamToolCall(ctx, client, "radius_list_applications", map[string]interface{}{
 		"namespace": "default",
-	})
-	if err != nil {
+	}); err != nil {
 		fmt.Printf("Error listing applications: %v\n", err)
-	} else {
-		printToolResponse("radius_list_applications", listAppsResponse)
 	}
-}
 
-func sendInitializeRequest(serverURL string) (*MCPResponse, error) {
-	req := MCPRequest{
-		Version:     "0.1",
-		MessageType: "initializeRequest",
-		Message:     map[string]interface{}{},
+	// Example: Deploy an application, rendering a progress bar.
+	if err := streamToolCall(ctx, client, "radius_deploy_application", map[string]interface{}{
+		"namespace":   "default",
+		"application": "demo",
+	}); err != nil {
+		fmt.Printf("Error deploying application: %v\n", err)
 	}
-
-	return sendRequest(serverURL, req)
 }
 
-func sendToolCallRequest(serverURL, toolName string, parameters map[string]interface{}) (*MCPResponse, error) {
-	req := MCPRequest{
-		Version:     "0.1",
-		MessageType: "toolCallRequest",
-		Message: map[string]interface{}{
-			"toolCalls": []map[string]interface{}{
-				{
-					"toolCallId": "call-" + toolName,
-					"name":       toolName,
-					"parameters": parameters,
-				},
-			},
-		},
-	}
-
-	return sendRequest(serverURL, req)
-}
-
-func sendRequest(serverURL string, req MCPRequest) (*MCPResponse, error) {
-	reqBody, err := json.Marshal(req)
+// streamToolCall invokes toolName via client.CallToolStream and renders
+// its events as they arrive: a progress bar for deploy-style tools
+// (those reporting ToolEventProgress), kubectl-logs-style streamed lines
+// for everything else, then the final result.
+func streamToolCall(ctx context.Context, client *Client, toolName string, arguments map[string]interface{}) error {
+	events, err := client.CallToolStream(ctx, toolName, arguments)
 	if err != nil {
-		return nil, fmt.Errorf("error marshaling request: %v", err)
+		return err
 	}
 
-	resp, err := http.Post(serverURL, "application/json", bytes.NewBuffer(reqBody))
-	if err != nil {
-		return nil, fmt.Errorf("error sending request: %v", err)
+	for ev := range events {
+		switch ev.Kind {
+		case ToolEventProgress:
+			printProgressBar(ev.Message, ev.Progress)
+		case ToolEventLog, ToolEventPartialOutput:
+			fmt.Println(ev.Message)
+		case ToolEventResult:
+			printToolResponse(toolName, ev.Result)
+		case ToolEventError:
+			return ev.Err
+		}
 	}
-	defer resp.Body.Close()
+	return nil
+}
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("error reading response: %v", err)
+// printProgressBar renders a single-line progress bar, overwriting the
+// previous one.
+func printProgressBar(message string, percent float64) {
+	const width = 30
+	filled := int(percent / 100 * width)
+	if filled > width {
+		filled = width
 	}
+	if filled < 0 {
+		filled = 0
+	}
+	bar := strings.Repeat("=", filled) + strings.Repeat(" ", width-filled)
+	fmt.Printf("\r[%s] %3.0f%% %s", bar, percent, message)
+	if percent >= 100 {
+		fmt.Println()
+	}
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("server returned error: %s - %s", resp.Status, string(body))
+// newTransport builds the Transport named by transportName, pointed at
+// target. For "stdio", target is the command (plus args, space
+// separated) to launch the MCP server as a subprocess; for "http", "sse",
+// and "unix" it's the server URL. A unix:// target is always dialed as
+// UnixTransport regardless of transportName, the same way a yggdrasilctl
+// admin socket is selected by its URL scheme.
+func newTransport(ctx context.Context, transportName, target string) (Transport, error) {
+	if strings.HasPrefix(target, "unix://") {
+		return NewUnixTransport(ctx, target)
 	}
 
-	var mcpResponse MCPResponse
-	if err := json.Unmarshal(body, &mcpResponse); err != nil {
-		return nil, fmt.Errorf("error unmarshaling response: %v", err)
+	switch transportName {
+	case "http":
+		return NewHTTPTransport(target), nil
+	case "sse":
+		return NewSSETransport(ctx, target)
+	case "unix":
+		return NewUnixTransport(ctx, target)
+	case "stdio":
+		parts := strings.Fields(target)
+		if len(parts) == 0 {
+			return nil, fmt.Errorf("stdio transport requires a command to launch")
+		}
+		return NewStdioTransport(ctx, parts[0], parts[1:]...)
+	default:
+		return nil, fmt.Errorf("unknown transport %q (want http, stdio, sse, or unix)", transportName)
 	}
+}
+
+// sendInitializeRequest performs the MCP initialize handshake against client.
+func sendInitializeRequest(ctx context.Context, client *Client) (*InitializeResult, error) {
+	return client.Initialize(ctx)
+}
 
-	return &mcpResponse, nil
+// sendToolCallRequest invokes a single tool via client and waits for its result.
+func sendToolCallRequest(ctx context.Context, client *Client, toolName string, arguments map[string]interface{}) (*ToolsCallResult, error) {
+	return client.CallTool(ctx, toolName, arguments)
 }
 
-func printToolResponse(toolName string, response *MCPResponse) {
+func printToolResponse(toolName string, result *ToolsCallResult) {
 	fmt.Printf("Results for %s:\n", toolName)
 
-	if response.MessageType != "toolCallResponse" {
-		fmt.Printf("Unexpected message type: %s\n", response.MessageType)
+	if result.IsError {
+		for _, c := range result.Content {
+			if c.Text != "" {
+				fmt.Printf("Error: %s\n", c.Text)
+			}
+		}
+		fmt.Println()
 		return
 	}
 
-	toolCallResponses := response.Message["toolCallResponses"].([]interface{})
-	for _, tcr := range toolCallResponses {
-		resp := tcr.(map[string]interface{})
-
-		if errorMsg, hasError := resp["error"]; hasError {
-			fmt.Printf("Error: %s\n", errorMsg)
-			continue
-		}
-
-		results := resp["results"].(map[string]interface{})
-		output := results["output"].(string)
-		fmt.Printf("Output:\n%s\n", output)
-
-		if data, hasData := results["data"]; hasData {
+	for _, c := range result.Content {
+		switch c.Type {
+		case "text":
+			fmt.Printf("Output:\n%s\n", c.Text)
+		default:
+			if len(c.Data) == 0 {
+				continue
+			}
+			var pretty interface{}
+			if err := json.Unmarshal(c.Data, &pretty); err != nil {
+				continue
+			}
+			prettyData, _ := json.MarshalIndent(pretty, "", "  ")
 			fmt.Println("Structured data:")
-			prettyData, _ := json.MarshalIndent(data, "", "  ")
 			fmt.Println(string(prettyData))
 		}
 	}
@@ -0,0 +1,78 @@
+package main
+
+import "sync"
+
+// notificationBroker fans server-initiated notifications out to
+// whichever CallToolStream calls are currently interested, keyed by
+// progress token. It decouples a transport's single read-loop goroutine
+// from its subscribers: publish never blocks, so a slow consumer, an
+// absent one, or several concurrent ones can never stall the read loop
+// (and, transitively, the Send calls that loop also routes responses
+// for).
+//
+// Every transport that owns one (stdio, SSE, unix) must only ever call
+// publish and closeAll from that single read-loop goroutine — that's
+// what makes "publish never blocks" enough to guarantee the read loop
+// itself can never wedge, however much unsolicited notification traffic
+// a server sends.
+//
+// The cost of never blocking is that a subscriber whose channel is full
+// silently misses events rather than applying backpressure; callers
+// that need every event should drain their channel promptly.
+type notificationBroker struct {
+	mu   sync.Mutex
+	subs map[string]chan ToolEvent
+}
+
+func newNotificationBroker() *notificationBroker {
+	return &notificationBroker{subs: make(map[string]chan ToolEvent)}
+}
+
+// subscribe registers interest in notifications tagged with token and
+// returns the channel they'll arrive on plus a cancel func to stop
+// receiving them. Each token gets its own channel, so concurrent
+// CallToolStream calls on the same transport never see each other's
+// events.
+func (b *notificationBroker) subscribe(token string) (<-chan ToolEvent, func()) {
+	ch := make(chan ToolEvent, 64)
+
+	b.mu.Lock()
+	b.subs[token] = ch
+	b.mu.Unlock()
+
+	cancel := func() {
+		b.mu.Lock()
+		delete(b.subs, token)
+		b.mu.Unlock()
+	}
+	return ch, cancel
+}
+
+// publish routes ev to token's subscriber, if any. It never blocks: a
+// full subscriber channel or no subscriber at all just drops the event.
+// Callers must only invoke publish from the transport's single read
+// loop, never concurrently with itself.
+func (b *notificationBroker) publish(token string, ev ToolEvent) {
+	b.mu.Lock()
+	ch, ok := b.subs[token]
+	b.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	select {
+	case ch <- ev:
+	default:
+	}
+}
+
+// closeAll closes every outstanding subscriber channel, called once the
+// underlying transport's read loop exits for good.
+func (b *notificationBroker) closeAll() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for token, ch := range b.subs {
+		close(ch)
+		delete(b.subs, token)
+	}
+}
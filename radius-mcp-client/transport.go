@@ -0,0 +1,33 @@
+package main
+
+import "context"
+
+// Transport delivers a single JSON-RPC request to an MCP server and
+// returns its response. Implementations also let callers subscribe to
+// server-initiated notifications (progress and log messages) tagged
+// with a particular progress token.
+type Transport interface {
+	// Send delivers req and waits for the matching response. For
+	// notifications (req.ID == nil) it returns a nil response as soon
+	// as the message has been written.
+	Send(ctx context.Context, req JSONRPCRequest) (*JSONRPCResponse, error)
+
+	// Subscribe registers interest in notifications tagged with
+	// progressToken, returning a channel that delivers only those and a
+	// cancel func to unsubscribe once the caller is done. Implementations
+	// must keep delivering to however many tokens are subscribed at once
+	// without ever blocking their own read loop.
+	Subscribe(progressToken string) (<-chan ToolEvent, func())
+
+	// Close releases any resources (subprocess, connections) the
+	// transport holds.
+	Close() error
+}
+
+// ChunkedTransport is implemented by transports with no independent
+// channel for server-initiated notifications (namely HTTPTransport). It
+// lets a single long-running call stream its own progress/log/result
+// events back as they happen instead of blocking until completion.
+type ChunkedTransport interface {
+	SendChunked(ctx context.Context, req JSONRPCRequest) (<-chan StreamEvent, error)
+}
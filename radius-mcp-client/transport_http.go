@@ -0,0 +1,129 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// HTTPTransport sends each JSON-RPC request as a single HTTP POST and
+// reads the response from the body. It has no server-initiated stream
+// of its own; Subscribe returns a channel nothing will ever be
+// published to.
+type HTTPTransport struct {
+	serverURL string
+	client    *http.Client
+}
+
+// NewHTTPTransport returns a Transport that POSTs JSON-RPC requests to serverURL.
+func NewHTTPTransport(serverURL string) *HTTPTransport {
+	return &HTTPTransport{
+		serverURL: serverURL,
+		client:    &http.Client{},
+	}
+}
+
+func (t *HTTPTransport) Send(ctx context.Context, req JSONRPCRequest) (*JSONRPCResponse, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, t.serverURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("building request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	httpResp, err := t.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("sending request: %w", err)
+	}
+	defer httpResp.Body.Close()
+
+	respBody, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading response: %w", err)
+	}
+
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("server returned %s: %s", httpResp.Status, string(respBody))
+	}
+
+	if req.ID == nil {
+		return nil, nil
+	}
+	if len(respBody) == 0 {
+		return nil, fmt.Errorf("server returned an empty response to %s", req.Method)
+	}
+
+	var resp JSONRPCResponse
+	if err := json.Unmarshal(respBody, &resp); err != nil {
+		return nil, fmt.Errorf("unmarshaling response: %w", err)
+	}
+
+	return &resp, nil
+}
+
+// SendChunked POSTs req like Send, but expects the server to respond
+// with chunked transfer encoding: one JSON StreamEvent object per
+// chunk, ending with a "result" or "error" event. It implements
+// ChunkedTransport.
+func (t *HTTPTransport) SendChunked(ctx context.Context, req JSONRPCRequest) (<-chan StreamEvent, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, t.serverURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("building request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Accept", "application/jsonl")
+
+	httpResp, err := t.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("sending request: %w", err)
+	}
+
+	if httpResp.StatusCode != http.StatusOK {
+		defer httpResp.Body.Close()
+		respBody, _ := io.ReadAll(httpResp.Body)
+		return nil, fmt.Errorf("server returned %s: %s", httpResp.Status, string(respBody))
+	}
+
+	events := make(chan StreamEvent, 16)
+	go func() {
+		defer close(events)
+		defer httpResp.Body.Close()
+
+		decoder := json.NewDecoder(httpResp.Body)
+		for {
+			var ev StreamEvent
+			if err := decoder.Decode(&ev); err != nil {
+				if err != io.EOF {
+					events <- StreamEvent{Type: "error", Error: &JSONRPCError{Message: err.Error()}}
+				}
+				return
+			}
+			events <- ev
+		}
+	}()
+
+	return events, nil
+}
+
+// Subscribe is never actually used: CallToolStream detects that
+// HTTPTransport implements ChunkedTransport and streams via SendChunked
+// instead. It's here only to satisfy Transport.
+func (t *HTTPTransport) Subscribe(progressToken string) (<-chan ToolEvent, func()) {
+	return make(chan ToolEvent), func() {}
+}
+
+func (t *HTTPTransport) Close() error {
+	return nil
+}
@@ -0,0 +1,202 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// SSETransport is the classic MCP HTTP+SSE transport: a long-lived GET
+// on the event stream carries server -> client traffic (the paired POST
+// endpoint, responses, and notifications), while requests are sent as
+// short-lived POSTs to that endpoint.
+type SSETransport struct {
+	client  *http.Client
+	postURL string
+	sseBody io.Closer
+
+	mu      sync.Mutex
+	pending map[int64]chan *JSONRPCResponse
+	broker  *notificationBroker
+}
+
+// NewSSETransport opens sseURL and waits for the server to announce the
+// endpoint requests should be POSTed to.
+func NewSSETransport(ctx context.Context, sseURL string) (*SSETransport, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, sseURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("building SSE request: %w", err)
+	}
+	req.Header.Set("Accept", "text/event-stream")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to %s: %w", sseURL, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("server returned %s for SSE connect", resp.Status)
+	}
+
+	t := &SSETransport{
+		client:  &http.Client{},
+		sseBody: resp.Body,
+		pending: make(map[int64]chan *JSONRPCResponse),
+		broker:  newNotificationBroker(),
+	}
+
+	endpoint := make(chan string, 1)
+	go t.readEvents(resp.Body, endpoint)
+
+	select {
+	case t.postURL = <-endpoint:
+		return t, nil
+	case <-ctx.Done():
+		resp.Body.Close()
+		return nil, ctx.Err()
+	}
+}
+
+// readEvents parses "event: ...\ndata: ...\n\n" blocks off body. The
+// first "endpoint" event publishes the POST URL; "message" events carry
+// JSON-RPC responses and notifications, routed the same way the stdio
+// transport routes its lines. It is this transport's single read loop
+// (see notificationBroker), so t.broker.publish and reads of t.pending
+// only ever happen here.
+func (t *SSETransport) readEvents(body io.Reader, endpoint chan<- string) {
+	defer t.broker.closeAll()
+
+	scanner := bufio.NewScanner(body)
+
+	var event string
+	var data bytes.Buffer
+	flush := func() {
+		if data.Len() == 0 {
+			event = ""
+			return
+		}
+		payload := strings.TrimSpace(data.String())
+		switch event {
+		case "endpoint":
+			select {
+			case endpoint <- payload:
+			default:
+			}
+		default:
+			t.handleMessage([]byte(payload))
+		}
+		event = ""
+		data.Reset()
+	}
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			flush()
+		case strings.HasPrefix(line, "event:"):
+			event = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			data.WriteString(strings.TrimPrefix(line, "data:"))
+		}
+	}
+	flush()
+
+	t.mu.Lock()
+	for id, ch := range t.pending {
+		close(ch)
+		delete(t.pending, id)
+	}
+	t.mu.Unlock()
+}
+
+func (t *SSETransport) handleMessage(payload []byte) {
+	var msg wireMessage
+	if err := json.Unmarshal(payload, &msg); err != nil {
+		return
+	}
+
+	if msg.Method != "" {
+		if token, ev, ok := decodeNotificationEvent(msg.Method, msg.Params); ok {
+			t.broker.publish(token, ev)
+		}
+		return
+	}
+
+	if msg.ID == nil {
+		return
+	}
+
+	t.mu.Lock()
+	ch, ok := t.pending[*msg.ID]
+	if ok {
+		delete(t.pending, *msg.ID)
+	}
+	t.mu.Unlock()
+
+	if ok {
+		ch <- &JSONRPCResponse{JSONRPC: msg.JSONRPC, ID: msg.ID, Result: msg.Result, Error: msg.Error}
+		close(ch)
+	}
+}
+
+func (t *SSETransport) Send(ctx context.Context, req JSONRPCRequest) (*JSONRPCResponse, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling request: %w", err)
+	}
+
+	var respCh chan *JSONRPCResponse
+	if req.ID != nil {
+		respCh = make(chan *JSONRPCResponse, 1)
+		t.mu.Lock()
+		t.pending[*req.ID] = respCh
+		t.mu.Unlock()
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, t.postURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("building request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	httpResp, err := t.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("posting request: %w", err)
+	}
+	httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK && httpResp.StatusCode != http.StatusAccepted {
+		return nil, fmt.Errorf("server returned %s for posted request", httpResp.Status)
+	}
+
+	if respCh == nil {
+		return nil, nil
+	}
+
+	select {
+	case resp, ok := <-respCh:
+		if !ok {
+			return nil, fmt.Errorf("SSE stream closed before a reply arrived")
+		}
+		return resp, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (t *SSETransport) Subscribe(progressToken string) (<-chan ToolEvent, func()) {
+	return t.broker.subscribe(progressToken)
+}
+
+// Close releases the long-lived SSE connection, which also unblocks
+// readEvents.
+func (t *SSETransport) Close() error {
+	return t.sseBody.Close()
+}
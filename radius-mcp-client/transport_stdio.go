@@ -0,0 +1,186 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os/exec"
+	"sync"
+)
+
+// wireMessage is the superset of fields a line of stdio (or SSE) traffic
+// may carry. A populated Method marks it as a server-initiated request
+// or notification; otherwise it's a response to one of our requests.
+type wireMessage struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      *int64          `json:"id,omitempty"`
+	Method  string          `json:"method,omitempty"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *JSONRPCError   `json:"error,omitempty"`
+}
+
+// StdioTransport launches the MCP server as a subprocess and frames
+// JSON-RPC messages as newline-delimited JSON over its stdin/stdout.
+// Subprocess stderr is logged separately and never treated as protocol
+// traffic.
+type StdioTransport struct {
+	cmd   *exec.Cmd
+	stdin io.WriteCloser
+
+	mu      sync.Mutex
+	pending map[int64]chan *JSONRPCResponse
+	broker  *notificationBroker
+}
+
+// NewStdioTransport starts command with args and wires up its stdio for
+// MCP framing.
+func NewStdioTransport(ctx context.Context, command string, args ...string) (*StdioTransport, error) {
+	cmd := exec.CommandContext(ctx, command, args...)
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, fmt.Errorf("opening stdin pipe: %w", err)
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("opening stdout pipe: %w", err)
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return nil, fmt.Errorf("opening stderr pipe: %w", err)
+	}
+
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("starting %s: %w", command, err)
+	}
+
+	t := &StdioTransport{
+		cmd:     cmd,
+		stdin:   stdin,
+		pending: make(map[int64]chan *JSONRPCResponse),
+		broker:  newNotificationBroker(),
+	}
+
+	go t.logStderr(stderr)
+	go t.readLoop(stdout)
+
+	return t, nil
+}
+
+// logStderr copies the subprocess's stderr line by line so it's visible
+// for debugging without ever being mistaken for protocol traffic.
+func (t *StdioTransport) logStderr(stderr io.Reader) {
+	scanner := bufio.NewScanner(stderr)
+	for scanner.Scan() {
+		log.Printf("[mcp-server] %s", scanner.Text())
+	}
+}
+
+// readLoop reads one JSON object per line from the subprocess's stdout,
+// ignoring blank lines, and routes each to the pending call it answers
+// or to the notification broker. It is this transport's single read loop
+// (see notificationBroker), so t.broker.publish and reads of t.pending
+// only ever happen here.
+func (t *StdioTransport) readLoop(stdout io.Reader) {
+	defer t.broker.closeAll()
+
+	reader := bufio.NewReader(stdout)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if trimmed := bytes.TrimSpace(line); len(trimmed) > 0 {
+			t.handleLine(trimmed)
+		}
+		if err != nil {
+			t.failPending(err)
+			return
+		}
+	}
+}
+
+func (t *StdioTransport) handleLine(line []byte) {
+	var msg wireMessage
+	if err := json.Unmarshal(line, &msg); err != nil {
+		log.Printf("mcp: discarding malformed line from server: %v", err)
+		return
+	}
+
+	if msg.Method != "" {
+		if token, ev, ok := decodeNotificationEvent(msg.Method, msg.Params); ok {
+			t.broker.publish(token, ev)
+		}
+		return
+	}
+
+	if msg.ID == nil {
+		return
+	}
+
+	t.mu.Lock()
+	ch, ok := t.pending[*msg.ID]
+	if ok {
+		delete(t.pending, *msg.ID)
+	}
+	t.mu.Unlock()
+
+	if ok {
+		ch <- &JSONRPCResponse{JSONRPC: msg.JSONRPC, ID: msg.ID, Result: msg.Result, Error: msg.Error}
+		close(ch)
+	}
+}
+
+func (t *StdioTransport) failPending(err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for id, ch := range t.pending {
+		close(ch)
+		delete(t.pending, id)
+	}
+}
+
+func (t *StdioTransport) Send(ctx context.Context, req JSONRPCRequest) (*JSONRPCResponse, error) {
+	line, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling request: %w", err)
+	}
+	line = append(line, '\n')
+
+	var respCh chan *JSONRPCResponse
+	if req.ID != nil {
+		respCh = make(chan *JSONRPCResponse, 1)
+		t.mu.Lock()
+		t.pending[*req.ID] = respCh
+		t.mu.Unlock()
+	}
+
+	if _, err := t.stdin.Write(line); err != nil {
+		return nil, fmt.Errorf("writing to subprocess stdin: %w", err)
+	}
+
+	if respCh == nil {
+		return nil, nil
+	}
+
+	select {
+	case resp, ok := <-respCh:
+		if !ok {
+			return nil, fmt.Errorf("mcp server closed stdout before replying")
+		}
+		return resp, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (t *StdioTransport) Subscribe(progressToken string) (<-chan ToolEvent, func()) {
+	return t.broker.subscribe(progressToken)
+}
+
+func (t *StdioTransport) Close() error {
+	t.stdin.Close()
+	return t.cmd.Wait()
+}
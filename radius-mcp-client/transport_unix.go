@@ -0,0 +1,188 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+)
+
+// UnixTransport dials a Unix domain socket and frames JSON-RPC messages
+// as newline-delimited JSON, the same way StdioTransport does over a
+// subprocess's stdio. It's selected by a unix:// URL, e.g.
+// "unix:///var/run/radius-mcp.sock" — the same convention tools like
+// yggdrasilctl use for their local admin socket.
+//
+// PeerCredentials exposes the SO_PEERCRED primitive the original request
+// was built around, but that's as far as this repo goes: there's no
+// server package here, only this client's dialer, so nothing gates
+// destructive tools by UID against a policy file. That enforcement has
+// to live in whatever accepts the connection; treat it as out of scope
+// and untracked here, not as something already handled on the other
+// end.
+type UnixTransport struct {
+	conn net.Conn
+
+	mu      sync.Mutex
+	pending map[int64]chan *JSONRPCResponse
+	broker  *notificationBroker
+}
+
+// unixSocketPath extracts the filesystem path from a unix:// URL, e.g.
+// "unix:///var/run/radius-mcp.sock" -> "/var/run/radius-mcp.sock".
+func unixSocketPath(serverURL string) (string, error) {
+	path := strings.TrimPrefix(serverURL, "unix://")
+	if path == serverURL {
+		return "", fmt.Errorf("not a unix:// URL: %s", serverURL)
+	}
+	if path == "" {
+		return "", fmt.Errorf("unix:// URL has no socket path: %s", serverURL)
+	}
+	return path, nil
+}
+
+// NewUnixTransport dials the Unix domain socket named by a unix:// URL.
+func NewUnixTransport(ctx context.Context, serverURL string) (*UnixTransport, error) {
+	path, err := unixSocketPath(serverURL)
+	if err != nil {
+		return nil, err
+	}
+
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "unix", path)
+	if err != nil {
+		return nil, fmt.Errorf("dialing %s: %w", serverURL, err)
+	}
+
+	t := &UnixTransport{
+		conn:    conn,
+		pending: make(map[int64]chan *JSONRPCResponse),
+		broker:  newNotificationBroker(),
+	}
+
+	go t.readLoop()
+
+	return t, nil
+}
+
+// readLoop reads one JSON object per line off the socket, ignoring
+// blank lines, and routes each to the pending call it answers or to the
+// notification broker. It is this transport's single read loop (see
+// notificationBroker), so t.broker.publish and reads of t.pending only
+// ever happen here.
+func (t *UnixTransport) readLoop() {
+	defer t.broker.closeAll()
+
+	reader := bufio.NewReader(t.conn)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if trimmed := bytes.TrimSpace(line); len(trimmed) > 0 {
+			t.handleLine(trimmed)
+		}
+		if err != nil {
+			t.failPending()
+			return
+		}
+	}
+}
+
+func (t *UnixTransport) handleLine(line []byte) {
+	var msg wireMessage
+	if err := json.Unmarshal(line, &msg); err != nil {
+		return
+	}
+
+	if msg.Method != "" {
+		if token, ev, ok := decodeNotificationEvent(msg.Method, msg.Params); ok {
+			t.broker.publish(token, ev)
+		}
+		return
+	}
+
+	if msg.ID == nil {
+		return
+	}
+
+	t.mu.Lock()
+	ch, ok := t.pending[*msg.ID]
+	if ok {
+		delete(t.pending, *msg.ID)
+	}
+	t.mu.Unlock()
+
+	if ok {
+		ch <- &JSONRPCResponse{JSONRPC: msg.JSONRPC, ID: msg.ID, Result: msg.Result, Error: msg.Error}
+		close(ch)
+	}
+}
+
+func (t *UnixTransport) failPending() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for id, ch := range t.pending {
+		close(ch)
+		delete(t.pending, id)
+	}
+}
+
+func (t *UnixTransport) Send(ctx context.Context, req JSONRPCRequest) (*JSONRPCResponse, error) {
+	line, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("marshaling request: %w", err)
+	}
+	line = append(line, '\n')
+
+	var respCh chan *JSONRPCResponse
+	if req.ID != nil {
+		respCh = make(chan *JSONRPCResponse, 1)
+		t.mu.Lock()
+		t.pending[*req.ID] = respCh
+		t.mu.Unlock()
+	}
+
+	if _, err := t.conn.Write(line); err != nil {
+		return nil, fmt.Errorf("writing to socket: %w", err)
+	}
+
+	if respCh == nil {
+		return nil, nil
+	}
+
+	select {
+	case resp, ok := <-respCh:
+		if !ok {
+			return nil, fmt.Errorf("mcp server closed the socket before replying")
+		}
+		return resp, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (t *UnixTransport) Subscribe(progressToken string) (<-chan ToolEvent, func()) {
+	return t.broker.subscribe(progressToken)
+}
+
+func (t *UnixTransport) Close() error {
+	return t.conn.Close()
+}
+
+// PeerCredentials describes the process on the other end of the socket,
+// as reported by the kernel rather than anything that process claims
+// about itself — the primitive SO_PEERCRED provides on Linux.
+type PeerCredentials struct {
+	PID int32
+	UID uint32
+	GID uint32
+}
+
+// PeerCredentials reads the kernel's record of the process on the other
+// end of the socket. The platform-specific lookup is in
+// transport_unix_linux.go / transport_unix_other.go.
+func (t *UnixTransport) PeerCredentials() (*PeerCredentials, error) {
+	return peerCredentials(t.conn)
+}
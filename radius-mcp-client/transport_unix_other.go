@@ -0,0 +1,15 @@
+//go:build !linux
+
+package main
+
+import (
+	"fmt"
+	"net"
+	"runtime"
+)
+
+// peerCredentials reports that SO_PEERCRED isn't available: it's a
+// Linux-specific sockopt, and this client also runs on macOS.
+func peerCredentials(conn net.Conn) (*PeerCredentials, error) {
+	return nil, fmt.Errorf("peer credentials: not supported on %s", runtime.GOOS)
+}